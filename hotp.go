@@ -0,0 +1,89 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"hash"
+)
+
+// Hotp holds the state to calculate HMAC-Based One-Time Passwords as
+// described in IETF RFC 4226. See https://tools.ietf.org/html/rfc4226
+type Hotp struct {
+	key     []byte
+	counter uint64
+	f       func() hash.Hash
+}
+
+// NewHotpSha1 returns a Hotp using the provided key, Sha1 hashes and an
+// initial counter value.
+func NewHotpSha1(key []byte, counter uint64) *Hotp {
+	return &Hotp{
+		key:     key,
+		counter: counter,
+		f:       sha1.New,
+	}
+}
+
+// NewHotpSha256 returns a Hotp using the provided key, Sha256 hashes and an
+// initial counter value.
+func NewHotpSha256(key []byte, counter uint64) *Hotp {
+	return &Hotp{
+		key:     key,
+		counter: counter,
+		f:       sha256.New,
+	}
+}
+
+// NewHotpSha512 returns a Hotp using the provided key, Sha512 hashes and an
+// initial counter value.
+func NewHotpSha512(key []byte, counter uint64) *Hotp {
+	return &Hotp{
+		key:     key,
+		counter: counter,
+		f:       sha512.New,
+	}
+}
+
+// At returns the value of the one-time password at the given counter value.
+// It does not affect the Hotp's internal counter.
+func (h *Hotp) At(counter uint64) string {
+	return hotpAt(h.key, h.f, counter, 6)
+}
+
+// Next increments the Hotp's internal counter and returns the one-time
+// password for the new counter value.
+func (h *Hotp) Next() string {
+	h.counter++
+	return h.At(h.counter)
+}
+
+// hotpAt computes the HOTP value for key, hash constructor f, counter and
+// digit count as described in IETF RFC 4226 §5.3. Both HOTP and TOTP share
+// this truncation logic, with TOTP deriving its counter from a time step.
+func hotpAt(key []byte, f func() hash.Hash, counter uint64, digits int) string {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, counter)
+
+	// calculate the hash
+	mac := hmac.New(f, key)
+	mac.Write(b)
+	c := mac.Sum(nil)
+
+	// apply a window to select 4 bytes
+	i := c[len(c)-1] & 0xf
+	u := binary.BigEndian.Uint32(c[i:i+4]) & 0x7fffffff
+
+	// calculate the final value, truncated to the requested number of
+	// digits; mod is computed in 64 bits since 10^10 overflows uint32
+	mod := uint64(1)
+	for n := digits; n > 0; n-- {
+		mod *= 10
+	}
+	p := uint64(u) % mod
+
+	return fmt.Sprintf("%0*d", digits, p)
+}
@@ -0,0 +1,128 @@
+package totp
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metadata carries the descriptive, non-cryptographic fields of an
+// otpauth:// provisioning URI.
+type Metadata struct {
+	Label       string
+	Issuer      string
+	AccountName string
+}
+
+// Parse parses an otpauth://totp/... URI as produced by Google Authenticator,
+// Authy, 1Password and similar apps, returning the Generator it describes
+// along with its descriptive Metadata.
+func Parse(uri string) (Generator, Metadata, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return Generator{}, Metadata{}, fmt.Errorf("totp: invalid otpauth uri: %w", err)
+	}
+	if u.Scheme != "otpauth" {
+		return Generator{}, Metadata{}, fmt.Errorf("totp: unsupported scheme %q", u.Scheme)
+	}
+	if u.Host != "totp" {
+		return Generator{}, Metadata{}, fmt.Errorf("totp: unsupported otpauth type %q", u.Host)
+	}
+
+	label, err := url.PathUnescape(strings.TrimPrefix(u.Path, "/"))
+	if err != nil {
+		return Generator{}, Metadata{}, fmt.Errorf("totp: invalid label: %w", err)
+	}
+
+	q := u.Query()
+
+	secret := q.Get("secret")
+	if secret == "" {
+		return Generator{}, Metadata{}, fmt.Errorf("totp: missing secret parameter")
+	}
+	key, err := NewFromBase32(secret)
+	if err != nil {
+		return Generator{}, Metadata{}, fmt.Errorf("totp: invalid secret: %w", err)
+	}
+
+	alg := strings.ToUpper(q.Get("algorithm"))
+	if alg == "" {
+		alg = "SHA1"
+	}
+
+	var g Generator
+	switch alg {
+	case "SHA1":
+		g = NewSha1(key)
+	case "SHA256":
+		g = NewSha256(key)
+	case "SHA512":
+		g = NewSha512(key)
+	default:
+		return Generator{}, Metadata{}, fmt.Errorf("totp: unsupported algorithm %q", alg)
+	}
+
+	if d := q.Get("digits"); d != "" {
+		n, err := strconv.Atoi(d)
+		if err != nil {
+			return Generator{}, Metadata{}, fmt.Errorf("totp: invalid digits %q", d)
+		}
+		g, err = g.WithDigits(n)
+		if err != nil {
+			return Generator{}, Metadata{}, err
+		}
+	}
+
+	if p := q.Get("period"); p != "" {
+		n, err := strconv.Atoi(p)
+		if err != nil || n <= 0 {
+			return Generator{}, Metadata{}, fmt.Errorf("totp: invalid period %q", p)
+		}
+		g.tx = time.Duration(n) * time.Second
+	}
+
+	issuer := q.Get("issuer")
+	accountName := label
+	if issuer != "" {
+		// The issuer query parameter is authoritative: strip it as a known
+		// "issuer:" prefix by length rather than splitting on the first
+		// colon, since the issuer itself may contain one (e.g. "Acme: Corp").
+		if prefix := issuer + ":"; strings.HasPrefix(label, prefix) {
+			accountName = strings.TrimSpace(label[len(prefix):])
+		}
+	} else if i := strings.Index(label, ":"); i >= 0 {
+		issuer = strings.TrimSpace(label[:i])
+		accountName = strings.TrimSpace(label[i+1:])
+	}
+
+	return g, Metadata{Label: label, Issuer: issuer, AccountName: accountName}, nil
+}
+
+// URI returns the otpauth://totp/... provisioning URI for g, suitable for
+// rendering as a QR code for authenticator apps. label and issuer are
+// percent-encoded per RFC 3986. issuer is included both as a prefix on the
+// label and as the issuer query parameter, since some clients only read one
+// of the two.
+func (g Generator) URI(label, issuer string) string {
+	v := url.Values{}
+	v.Set("secret", base32Encoding.EncodeToString(g.key))
+	v.Set("algorithm", g.alg)
+	v.Set("digits", strconv.Itoa(g.digits))
+	v.Set("period", strconv.Itoa(int(g.tx/time.Second)))
+
+	path := label
+	if issuer != "" {
+		v.Set("issuer", issuer)
+		path = issuer + ":" + label
+	}
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + path,
+		RawQuery: v.Encode(),
+	}
+	return u.String()
+}
@@ -0,0 +1,99 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestURIParseRoundtrip(t *testing.T) {
+	g := NewSha1([]byte("12345678901234567890"))
+	g, err := g.WithDigits(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uri := g.URI("alice@example.com", "Example")
+
+	g2, meta, err := Parse(uri)
+	if err != nil {
+		t.Fatalf("Parse(%q) returned error: %v", uri, err)
+	}
+
+	epoch := time.Unix(0, 0)
+	if got, want := g2.At(epoch), g.At(epoch); got != want {
+		t.Errorf("round-tripped Generator.At() = %q, want %q", got, want)
+	}
+	if meta.Issuer != "Example" {
+		t.Errorf("Issuer = %q, want %q", meta.Issuer, "Example")
+	}
+	if meta.AccountName != "alice@example.com" {
+		t.Errorf("AccountName = %q, want %q", meta.AccountName, "alice@example.com")
+	}
+}
+
+func TestURIParseRoundtripIssuerWithColon(t *testing.T) {
+	g := NewSha1([]byte("12345678901234567890"))
+
+	uri := g.URI("bob@example.com", "Acme: Corp")
+
+	_, meta, err := Parse(uri)
+	if err != nil {
+		t.Fatalf("Parse(%q) returned error: %v", uri, err)
+	}
+
+	// A colon inside the issuer must not be mistaken for the label's
+	// issuer:accountName separator.
+	if meta.Issuer != "Acme: Corp" {
+		t.Errorf("Issuer = %q, want %q", meta.Issuer, "Acme: Corp")
+	}
+	if meta.AccountName != "bob@example.com" {
+		t.Errorf("AccountName = %q, want %q", meta.AccountName, "bob@example.com")
+	}
+}
+
+func TestParseNoIssuer(t *testing.T) {
+	g := NewSha1([]byte("12345678901234567890"))
+
+	uri := g.URI("alice@example.com", "")
+
+	_, meta, err := Parse(uri)
+	if err != nil {
+		t.Fatalf("Parse(%q) returned error: %v", uri, err)
+	}
+	if meta.Issuer != "" {
+		t.Errorf("Issuer = %q, want empty", meta.Issuer)
+	}
+	if meta.AccountName != "alice@example.com" {
+		t.Errorf("AccountName = %q, want %q", meta.AccountName, "alice@example.com")
+	}
+}
+
+func TestParseLabelOnlyIssuerPrefix(t *testing.T) {
+	// No issuer query parameter at all: fall back to splitting the label on
+	// its first colon, as Google Authenticator-style URIs without an issuer
+	// parameter do.
+	uri := "otpauth://totp/Example:alice@example.com?secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	_, meta, err := Parse(uri)
+	if err != nil {
+		t.Fatalf("Parse(%q) returned error: %v", uri, err)
+	}
+	if meta.Issuer != "Example" {
+		t.Errorf("Issuer = %q, want %q", meta.Issuer, "Example")
+	}
+	if meta.AccountName != "alice@example.com" {
+		t.Errorf("AccountName = %q, want %q", meta.AccountName, "alice@example.com")
+	}
+}
+
+func TestParseRejectsUnsupportedScheme(t *testing.T) {
+	if _, _, err := Parse("http://totp/label?secret=GEZDGNBV"); err == nil {
+		t.Error("Parse with non-otpauth scheme returned nil error, want error")
+	}
+}
+
+func TestParseRejectsMissingSecret(t *testing.T) {
+	if _, _, err := Parse("otpauth://totp/label"); err == nil {
+		t.Error("Parse without a secret parameter returned nil error, want error")
+	}
+}
@@ -0,0 +1,46 @@
+package totp
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// base32Encoding is the RFC 4648 base32 alphabet without padding, as used by
+// the secret parameter of otpauth:// URIs and by user-facing secret display.
+var base32Encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewFromBase32 decodes secret, a base32 (RFC 4648, no padding) encoded key
+// as entered or displayed by authenticator apps, into raw key bytes.
+// Decoding is case-insensitive and tolerates the spaces authenticator apps
+// commonly group secrets with (e.g. "JBSW Y3DP EHPK 3PXP") as well as "="
+// padding, stripping both before decoding.
+func NewFromBase32(secret string) ([]byte, error) {
+	secret = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, secret)
+	secret = strings.TrimRight(strings.ToUpper(secret), "=")
+
+	key, err := base32Encoding.DecodeString(secret)
+	if err != nil {
+		return nil, fmt.Errorf("totp: invalid base32 secret: %w", err)
+	}
+	return key, nil
+}
+
+// GenerateSecret returns a cryptographically random key of the given length
+// in bytes, along with its RFC 4648 base32 encoding (uppercase, no padding)
+// suitable for display to users or embedding in an otpauth URI. 20 bytes is
+// recommended for SHA1 keys per IETF RFC 4226 §4 (R6).
+func GenerateSecret(bytes int) ([]byte, string, error) {
+	key := make([]byte, bytes)
+	if _, err := rand.Read(key); err != nil {
+		return nil, "", fmt.Errorf("totp: generating secret: %w", err)
+	}
+	return key, base32Encoding.EncodeToString(key), nil
+}
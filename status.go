@@ -0,0 +1,28 @@
+package totp
+
+import "time"
+
+// stepElapsed returns how far t has progressed past the start of its
+// current time step, floor-mod'd into [0, g.tx) so it stays in range even
+// for t before g.t0, where Go's % would otherwise return a negative value.
+func (g Generator) stepElapsed(t time.Time) time.Duration {
+	d := t.Sub(g.t0) % g.tx
+	if d < 0 {
+		d += g.tx
+	}
+	return d
+}
+
+// Remaining returns how long the one-time password at time t stays valid
+// before the next code takes over. UI consumers such as countdown rings and
+// TUIs use this to know when to refresh.
+func (g Generator) Remaining(t time.Time) time.Duration {
+	return g.tx - g.stepElapsed(t)
+}
+
+// Progress returns how far t falls into its current time step, as a
+// fraction from 0 (the step just started) to 1 (the step is about to roll
+// over). Countdown rings typically render 1-Progress(t) as the remaining arc.
+func (g Generator) Progress(t time.Time) float64 {
+	return float64(g.stepElapsed(t)) / float64(g.tx)
+}
@@ -0,0 +1,43 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRemainingAndProgress(t *testing.T) {
+	g := NewSha1([]byte("12345678901234567890"))
+
+	tests := []struct {
+		t            time.Time
+		wantRemain   time.Duration
+		wantProgress float64
+	}{
+		{time.Unix(0, 0), 30 * time.Second, 0},
+		{time.Unix(10, 0), 20 * time.Second, 1.0 / 3},
+		{time.Unix(29, 0), 1 * time.Second, 29.0 / 30},
+	}
+	for _, tt := range tests {
+		if got := g.Remaining(tt.t); got != tt.wantRemain {
+			t.Errorf("Remaining(%v) = %v, want %v", tt.t, got, tt.wantRemain)
+		}
+		if got := g.Progress(tt.t); got != tt.wantProgress {
+			t.Errorf("Progress(%v) = %v, want %v", tt.t, got, tt.wantProgress)
+		}
+	}
+}
+
+func TestProgressStaysInRangeBeforeEpoch(t *testing.T) {
+	g := NewSha1([]byte("12345678901234567890"))
+
+	before := time.Unix(0, 0).Add(-45 * time.Second)
+	p := g.Progress(before)
+	if p < 0 || p >= 1 {
+		t.Errorf("Progress(%v) = %v, want value in [0, 1)", before, p)
+	}
+
+	r := g.Remaining(before)
+	if r <= 0 || r > g.tx {
+		t.Errorf("Remaining(%v) = %v, want value in (0, %v]", before, r, g.tx)
+	}
+}
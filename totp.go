@@ -3,78 +3,92 @@
 package totp
 
 import (
-	"crypto/hmac"
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/sha512"
-	"encoding/binary"
 	"fmt"
 	"hash"
 	"time"
 )
 
+// defaultDigits is the code length used by the New* constructors, matching
+// the 6-digit codes mandated by IETF RFC 4226 §5.3 for interoperability with
+// most authenticator apps.
+const defaultDigits = 6
+
 // Generator holds the state to calculate Time-Based One-Time Passwords as
 // described in IETF RFC 6238. See https://tools.ietf.org/html/rfc6238
 type Generator struct {
-	key []byte
-	t0  time.Time
-	tx  time.Duration
-	f   func() hash.Hash
+	key    []byte
+	t0     time.Time
+	tx     time.Duration
+	f      func() hash.Hash
+	alg    string
+	digits int
 }
 
 // NewSha1 returns a Generator using the provided key, Sha1 hashes, a start time
 // at the Unix epoch and a time step of 30 seconds.
 func NewSha1(key []byte) Generator {
-	return Totp{
-		key: key,
-		t0:  time.Unix(0, 0),
-		tx:  30 * time.Second,
-		f:   sha1.New,
+	return Generator{
+		key:    key,
+		t0:     time.Unix(0, 0),
+		tx:     30 * time.Second,
+		f:      sha1.New,
+		alg:    "SHA1",
+		digits: defaultDigits,
 	}
 }
 
 // NewSha256 returns a Generator using the provided key, Sha256 hashes, a start
 // time at the Unix epoch and a time step of 30 seconds.
 func NewSha256(key []byte) Generator {
-	return Totp{
-		key: key,
-		t0:  time.Unix(0, 0),
-		tx:  30 * time.Second,
-		f:   sha256.New,
+	return Generator{
+		key:    key,
+		t0:     time.Unix(0, 0),
+		tx:     30 * time.Second,
+		f:      sha256.New,
+		alg:    "SHA256",
+		digits: defaultDigits,
 	}
 }
 
 // NewSha512 returns a Generator using the provided key, Sha512 hashes, a start
 // time at the Unix epoch and a time step of 30 seconds.
 func NewSha512(key []byte) Generator {
-	return Totp{
-		key: key,
-		t0:  time.Unix(0, 0),
-		tx:  30 * time.Second,
-		f:   sha512.New,
+	return Generator{
+		key:    key,
+		t0:     time.Unix(0, 0),
+		tx:     30 * time.Second,
+		f:      sha512.New,
+		alg:    "SHA512",
+		digits: defaultDigits,
 	}
 }
 
-// At returns the value of the one-time password at the time t.
-func (g Generator) At(t time.Time) string {
-	// calculate the time step
-	ct := t.Sub(g.t0).Nanoseconds() / g.tx.Nanoseconds()
-	b := make([]byte, 8)
-	binary.BigEndian.PutUint64(b, uint64(ct))
-
-	// calculate the hash
-	h := hmac.New(g.f, g.key)
-	h.Write(b)
-	c := h.Sum(nil)
-
-	// apply a window to select 4 bytes
-	i := c[len(c)-1] & 0xf
-	u := binary.BigEndian.Uint32(c[i : i+5])
+// WithDigits returns a copy of g configured to produce n-digit codes instead
+// of the default 6. n must be between 6 and 8 inclusive: the truncated
+// dynamic binary code (IETF RFC 4226 §5.3) is at most 2^31-1, so it can only
+// ever fill 9 digits non-uniformly and never reaches a 10th; 8 is the widest
+// code every peer authenticator app actually interoperates with.
+func (g Generator) WithDigits(n int) (Generator, error) {
+	if n < 6 || n > 8 {
+		return Generator{}, fmt.Errorf("totp: digits must be between 6 and 8, got %d", n)
+	}
+	g.digits = n
+	return g, nil
+}
 
-	// calculate the final value
-	p := (u & 0x7fffffff) % 1e6
+// StepAt returns the RFC 6238 T value: the time-step counter at time t.
+func (g Generator) StepAt(t time.Time) int64 {
+	return t.Sub(g.t0).Nanoseconds() / g.tx.Nanoseconds()
+}
 
-	return fmt.Sprintf("%06d", p)
+// At returns the value of the one-time password at the time t. TOTP is
+// simply HOTP applied to a counter derived from the time step, so the
+// truncation itself is delegated to the shared HOTP core.
+func (g Generator) At(t time.Time) string {
+	return hotpAt(g.key, g.f, uint64(g.StepAt(t)), g.digits)
 }
 
 // In returns the value of the one-time password after the duration d.
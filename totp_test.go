@@ -0,0 +1,102 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// hotpRFC4226Vectors are the HOTP-SHA1 test values from IETF RFC 4226
+// Appendix D, computed over the 20-byte ASCII key "12345678901234567890"
+// at counters 0-9.
+var hotpRFC4226Vectors = []string{
+	"755224", "287082", "359152", "969429", "338314",
+	"254676", "287922", "162583", "399871", "520489",
+}
+
+func TestHotpRFC4226Vectors(t *testing.T) {
+	h := NewHotpSha1([]byte("12345678901234567890"), 0)
+	for counter, want := range hotpRFC4226Vectors {
+		if got := h.At(uint64(counter)); got != want {
+			t.Errorf("At(%d) = %q, want %q", counter, got, want)
+		}
+	}
+}
+
+func TestHotpNextIncrementsCounter(t *testing.T) {
+	h := NewHotpSha1([]byte("12345678901234567890"), 0)
+	for _, want := range hotpRFC4226Vectors[1:] {
+		if got := h.Next(); got != want {
+			t.Errorf("Next() = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestWithDigitsRange(t *testing.T) {
+	g := NewSha1([]byte("12345678901234567890"))
+
+	for _, n := range []int{6, 7, 8} {
+		if _, err := g.WithDigits(n); err != nil {
+			t.Errorf("WithDigits(%d) returned error: %v", n, err)
+		}
+	}
+
+	// 9 and 10 digits exceed the 31-bit dynamic truncation's range: the
+	// leading digit could never be uniform, so WithDigits must reject them.
+	for _, n := range []int{5, 9, 10} {
+		if _, err := g.WithDigits(n); err == nil {
+			t.Errorf("WithDigits(%d) = nil error, want error", n)
+		}
+	}
+}
+
+func TestWithDigitsChangesCodeLength(t *testing.T) {
+	g := NewSha1([]byte("12345678901234567890"))
+	g8, err := g.WithDigits(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g8.At(time.Unix(0, 0)); len(got) != 8 {
+		t.Errorf("At() with 8 digits returned %q (len %d), want len 8", got, len(got))
+	}
+}
+
+func TestNewFromBase32StripsWhitespaceAndPadding(t *testing.T) {
+	want, err := NewFromBase32("JBSWY3DPEHPK3PXP")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, in := range []string{
+		"JBSW Y3DP EHPK 3PXP",
+		"jbswy3dpehpk3pxp",
+		"JBSWY3DPEHPK3PXP====",
+		"  JBSWY3DPEHPK3PXP  ",
+	} {
+		got, err := NewFromBase32(in)
+		if err != nil {
+			t.Errorf("NewFromBase32(%q) returned error: %v", in, err)
+			continue
+		}
+		if string(got) != string(want) {
+			t.Errorf("NewFromBase32(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenerateSecretRoundtrip(t *testing.T) {
+	key, encoded, err := GenerateSecret(20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(key) != 20 {
+		t.Errorf("len(key) = %d, want 20", len(key))
+	}
+
+	decoded, err := NewFromBase32(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decoded) != string(key) {
+		t.Errorf("NewFromBase32(GenerateSecret) = %q, want %q", decoded, key)
+	}
+}
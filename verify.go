@@ -0,0 +1,32 @@
+package totp
+
+import (
+	"crypto/subtle"
+	"time"
+)
+
+// Verify reports whether code is the one-time password at time t, or at any
+// of the skew neighboring time steps before or after it. Allowing a small
+// skew tolerates clock drift between the generator and the verifier. Codes
+// are compared in constant time to avoid leaking timing information that
+// could help an attacker guess a valid code.
+//
+// Verify does not protect against replay of a previously accepted code;
+// callers that need that guarantee should track the last accepted step
+// (see StepAt) and reject any code at or before it.
+func (g Generator) Verify(code string, t time.Time, skew int) bool {
+	step := g.StepAt(t)
+	for i := -skew; i <= skew; i++ {
+		candidate := hotpAt(g.key, g.f, uint64(step+int64(i)), g.digits)
+		if subtle.ConstantTimeCompare([]byte(code), []byte(candidate)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyNow reports whether code is the one-time password at the current
+// time, within the given clock-skew window. See Verify.
+func (g Generator) VerifyNow(code string, skew int) bool {
+	return g.Verify(code, time.Now(), skew)
+}
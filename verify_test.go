@@ -0,0 +1,65 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStepAt(t *testing.T) {
+	g := NewSha1([]byte("12345678901234567890"))
+
+	tests := []struct {
+		t    time.Time
+		want int64
+	}{
+		{time.Unix(0, 0), 0},
+		{time.Unix(29, 0), 0},
+		{time.Unix(30, 0), 1},
+		{time.Unix(59, 0), 1},
+		{time.Unix(60, 0), 2},
+	}
+	for _, tt := range tests {
+		if got := g.StepAt(tt.t); got != tt.want {
+			t.Errorf("StepAt(%v) = %d, want %d", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestVerifyAcceptsWithinSkewWindow(t *testing.T) {
+	g := NewSha1([]byte("12345678901234567890"))
+
+	now := time.Unix(1000*30, 0)
+	code := g.At(now.Add(2 * 30 * time.Second)) // one step inside a skew of 2
+
+	if !g.Verify(code, now, 2) {
+		t.Error("Verify() = false for a code within the skew window, want true")
+	}
+}
+
+func TestVerifyRejectsOutsideSkewWindow(t *testing.T) {
+	g := NewSha1([]byte("12345678901234567890"))
+
+	now := time.Unix(1000*30, 0)
+	code := g.At(now.Add(3 * 30 * time.Second)) // one step outside a skew of 2
+
+	if g.Verify(code, now, 2) {
+		t.Error("Verify() = true for a code outside the skew window, want false")
+	}
+}
+
+func TestVerifyRejectsWrongCode(t *testing.T) {
+	g := NewSha1([]byte("12345678901234567890"))
+
+	now := time.Unix(1000*30, 0)
+	if g.Verify("000000", now, 5) {
+		t.Error("Verify() = true for an arbitrary wrong code, want false")
+	}
+}
+
+func TestVerifyNowMatchesNow(t *testing.T) {
+	g := NewSha1([]byte("12345678901234567890"))
+
+	if !g.VerifyNow(g.Now(), 0) {
+		t.Error("VerifyNow(g.Now(), 0) = false, want true")
+	}
+}